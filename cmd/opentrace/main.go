@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"runtime"
 	"syscall"
 
 	"github.com/its-ernest/opentrace/core"
 	"github.com/its-ernest/opentrace/installer"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 func main() {
@@ -18,7 +20,7 @@ func main() {
 		Short: "Modular OSINT pipeline runner",
 	}
 
-	root.AddCommand(runCmd(), installCmd(), uninstallCmd(), modulesCmd())
+	root.AddCommand(runCmd(), installCmd(), uninstallCmd(), modulesCmd(), keysCmd(), updateCmd(), linkCmd(), unlinkCmd(), renderCmd())
 
 	if err := root.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -27,7 +29,9 @@ func main() {
 }
 
 func runCmd() *cobra.Command {
-	return &cobra.Command{
+	var jobs int
+
+	cmd := &cobra.Command{
 		Use:     "run <pipeline.yaml>",
 		Short:   "Run a pipeline",
 		Aliases: []string{"-r"},
@@ -40,26 +44,155 @@ func runCmd() *cobra.Command {
 
 			reg := installer.LoadRegistry()
 			for _, m := range p.Modules {
-				if _, ok := reg[m.Name]; !ok {
+				entry, ok := reg[m.Name]
+				if !ok {
 					return fmt.Errorf("module %q not installed — run: opentrace install %s", m.Name, m.Name)
 				}
+				if m.Version != "" {
+					satisfies, err := installer.SatisfiesConstraint(entry.Version, m.Version)
+					if err != nil {
+						return fmt.Errorf("module %q: %w", m.Name, err)
+					}
+					if !satisfies {
+						return fmt.Errorf(
+							"module %q@%s installed but pipeline requires %s — run: opentrace install %s@%s",
+							m.Name, entry.Version, m.Version, m.Name, m.Version,
+						)
+					}
+				}
 			}
 
 			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 			defer cancel()
 
-			return core.Run(ctx, p, installer.BinDir())
+			return core.Run(ctx, p, installer.BinDir(), jobs)
 		},
 	}
+	cmd.Flags().IntVar(&jobs, "jobs", runtime.NumCPU(), "number of steps to run concurrently")
+	return cmd
 }
 
-func installCmd() *cobra.Command {
+func renderCmd() *cobra.Command {
 	return &cobra.Command{
+		Use:   "render <pipeline.yaml>",
+		Short: "Print a pipeline's fully expanded YAML, with templates resolved",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := core.Load(args[0])
+			if err != nil {
+				return err
+			}
+			out, err := yaml.Marshal(p)
+			if err != nil {
+				return err
+			}
+			fmt.Print(string(out))
+			return nil
+		},
+	}
+}
+
+func installCmd() *cobra.Command {
+	var allowUntrusted bool
+
+	cmd := &cobra.Command{
 		Use:   "install <module>",
 		Short: "Install a module from opentrace-modules",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return installer.Install(args[0])
+			return installer.Install(args[0], allowUntrusted)
+		},
+	}
+	cmd.Flags().BoolVar(&allowUntrusted, "allow-untrusted", false,
+		"install even if the manifest signature cannot be verified")
+	return cmd
+}
+
+func updateCmd() *cobra.Command {
+	var allowUntrusted bool
+
+	cmd := &cobra.Command{
+		Use:   "update [module...]",
+		Short: "Refresh installed modules from their cached repos",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return installer.Update(args, allowUntrusted)
+		},
+	}
+	cmd.Flags().BoolVar(&allowUntrusted, "allow-untrusted", false,
+		"update even if the manifest signature cannot be verified")
+	return cmd
+}
+
+func linkCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "link <path>",
+		Short: "Register a local module directory, rebuilt on run when its source changes",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return installer.Link(args[0])
+		},
+	}
+}
+
+func unlinkCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlink <name>",
+		Short: "Remove a module registered with link",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return installer.Unlink(args[0])
+		},
+	}
+}
+
+func keysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage trusted signing keys",
+	}
+	cmd.AddCommand(keysAddCmd(), keysListCmd(), keysRemoveCmd())
+	return cmd
+}
+
+func keysAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <fingerprint> <key-path>",
+		Short: "Trust a signing key",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return installer.AddTrustedKey(args[0], args[1])
+		},
+	}
+}
+
+func keysListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List trusted signing keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fingerprints, err := installer.ListTrustedKeys()
+			if err != nil {
+				return err
+			}
+			if len(fingerprints) == 0 {
+				fmt.Println("  no trusted keys — run: opentrace keys add <fingerprint> <key-path>")
+				return nil
+			}
+			for _, fp := range fingerprints {
+				fmt.Println("  " + fp)
+			}
+			return nil
+		},
+	}
+}
+
+func keysRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <fingerprint>",
+		Short: "Untrust a signing key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return installer.RemoveTrustedKey(args[0])
 		},
 	}
 }
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+)
+
+// stepDependencies returns the step names a step depends on: the union of
+// its explicit Needs and the implicit edges harvested from every artifact
+// reference in Input and Inputs.
+func stepDependencies(step Step) []string {
+	seen := map[string]bool{}
+	var deps []string
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			deps = append(deps, name)
+		}
+	}
+
+	for _, n := range step.Needs {
+		add(n)
+	}
+	if ref, ok := asArtifactRef(step.Input); ok {
+		add(ref.From)
+	}
+	for _, raw := range step.Inputs {
+		if ref, ok := asArtifactRef(raw); ok {
+			add(ref.From)
+		}
+	}
+
+	return deps
+}
+
+// buildGraph computes the dependency edges for every step and validates that
+// every referenced step name actually exists in the pipeline.
+func buildGraph(p *Pipeline) (map[string][]string, error) {
+	names := map[string]bool{}
+	for _, s := range p.Modules {
+		if names[s.Name] {
+			return nil, fmt.Errorf("duplicate step name %q", s.Name)
+		}
+		names[s.Name] = true
+	}
+
+	graph := make(map[string][]string, len(p.Modules))
+	for _, s := range p.Modules {
+		deps := stepDependencies(s)
+		for _, d := range deps {
+			if !names[d] {
+				return nil, fmt.Errorf("[%s] depends on unknown step %q", s.Name, d)
+			}
+		}
+		graph[s.Name] = deps
+	}
+	return graph, nil
+}
+
+// topoOrder groups steps into waves: every step in wave N has its
+// dependencies satisfied by waves 0..N-1, so a wave's steps can all run
+// concurrently. When the graph cannot be fully ordered, returns an error
+// listing every step left unordered: the steps in a cycle and any steps that
+// depend on them.
+func topoOrder(graph map[string][]string) ([][]string, error) {
+	remaining := make(map[string][]string, len(graph))
+	for name, deps := range graph {
+		remaining[name] = append([]string(nil), deps...)
+	}
+
+	var waves [][]string
+	for len(remaining) > 0 {
+		var wave []string
+		for name, deps := range remaining {
+			if len(deps) == 0 {
+				wave = append(wave, name)
+			}
+		}
+		if len(wave) == 0 {
+			var stuck []string
+			for name := range remaining {
+				stuck = append(stuck, name)
+			}
+			sort.Strings(stuck)
+			return nil, fmt.Errorf("cycle detected among steps: %v", stuck)
+		}
+		sort.Strings(wave)
+		waves = append(waves, wave)
+
+		for _, name := range wave {
+			delete(remaining, name)
+		}
+		for name, deps := range remaining {
+			var next []string
+			for _, d := range deps {
+				if !contains(wave, d) {
+					next = append(next, d)
+				}
+			}
+			remaining[name] = next
+		}
+	}
+	return waves, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
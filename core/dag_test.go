@@ -0,0 +1,86 @@
+package core
+
+import "testing"
+
+func TestBuildGraphImplicitEdges(t *testing.T) {
+	p := &Pipeline{
+		Modules: []Step{
+			{Name: "import"},
+			{Name: "enrich", Input: map[string]any{"from": "import", "artifact": "graph"}},
+			{Name: "report", Inputs: map[string]any{
+				"graph": map[string]any{"from": "enrich", "artifact": "graph"},
+			}, Needs: []string{"import"}},
+		},
+	}
+
+	graph, err := buildGraph(p)
+	if err != nil {
+		t.Fatalf("buildGraph returned error: %v", err)
+	}
+
+	if deps := graph["enrich"]; len(deps) != 1 || deps[0] != "import" {
+		t.Errorf("enrich deps = %v, want [import]", deps)
+	}
+	if !contains(graph["report"], "enrich") || !contains(graph["report"], "import") {
+		t.Errorf("report deps = %v, want both enrich and import", graph["report"])
+	}
+}
+
+func TestBuildGraphUnknownDependency(t *testing.T) {
+	p := &Pipeline{
+		Modules: []Step{
+			{Name: "a", Needs: []string{"nonexistent"}},
+		},
+	}
+	if _, err := buildGraph(p); err == nil {
+		t.Error("expected error for dependency on unknown step, got nil")
+	}
+}
+
+func TestBuildGraphDuplicateStep(t *testing.T) {
+	p := &Pipeline{
+		Modules: []Step{
+			{Name: "a"},
+			{Name: "a"},
+		},
+	}
+	if _, err := buildGraph(p); err == nil {
+		t.Error("expected error for duplicate step name, got nil")
+	}
+}
+
+func TestTopoOrderWaves(t *testing.T) {
+	graph := map[string][]string{
+		"a": nil,
+		"b": nil,
+		"c": {"a", "b"},
+		"d": {"c"},
+	}
+
+	waves, err := topoOrder(graph)
+	if err != nil {
+		t.Fatalf("topoOrder returned error: %v", err)
+	}
+	if len(waves) != 3 {
+		t.Fatalf("got %d waves, want 3: %v", len(waves), waves)
+	}
+	if !contains(waves[0], "a") || !contains(waves[0], "b") {
+		t.Errorf("wave 0 = %v, want [a b]", waves[0])
+	}
+	if len(waves[1]) != 1 || waves[1][0] != "c" {
+		t.Errorf("wave 1 = %v, want [c]", waves[1])
+	}
+	if len(waves[2]) != 1 || waves[2][0] != "d" {
+		t.Errorf("wave 2 = %v, want [d]", waves[2])
+	}
+}
+
+func TestTopoOrderCycle(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b"},
+		"b": {"a"},
+	}
+	if _, err := topoOrder(graph); err == nil {
+		t.Error("expected error for cyclic graph, got nil")
+	}
+}
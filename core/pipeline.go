@@ -1,12 +1,9 @@
 package core
 
 import (
-	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
 	"gopkg.in/yaml.v3"
@@ -20,16 +17,22 @@ PIPELINE MODEL
 - Stdout is ignored
 - Stderr is operator-facing
 - Exit code is truth
+- Independent steps (no dependency between them) run concurrently
 */
 
 type Pipeline struct {
-	Modules []Step `yaml:"modules"`
+	Modules []Step         `yaml:"modules"`
+	Extends string         `yaml:"extends"` // template to render this pipeline from, e.g. "templates/contacts.yaml"
+	With    map[string]any `yaml:"with"`     // template variables, validated against the template's vars schema
 }
 
 type Step struct {
-	Name   string         `yaml:"name"`
-	Input  any            `yaml:"input"`  // string OR map (artifact reference)
-	Config map[string]any `yaml:"config"`
+	Name    string         `yaml:"name"`
+	Input   any            `yaml:"input"`  // string OR artifact reference (single-input form)
+	Inputs  map[string]any `yaml:"inputs"` // alias -> literal or artifact reference (multi-input form)
+	Config  map[string]any `yaml:"config"`
+	Version string         `yaml:"version"` // optional version constraint, e.g. "^1.2" or "1.2.x"
+	Needs   []string       `yaml:"needs"`   // explicit dependencies beyond what input/inputs imply
 }
 
 /*
@@ -42,6 +45,11 @@ INPUT FORMS
    input:
      from: contacts_graph
      artifact: graph
+
+3. Multiple artifact references, aliased for the module:
+   inputs:
+     graph: {from: contacts_graph, artifact: graph}
+     seeds: {from: seed_import, artifact: seeds}
 */
 
 type artifactRef struct {
@@ -70,7 +78,8 @@ type outputIndex struct {
 	} `json:"artifacts"`
 }
 
-// Load parses a pipeline YAML file
+// Load parses a pipeline YAML file. A pipeline that declares `extends:` is
+// resolved against its template and returned fully rendered.
 func Load(path string) (*Pipeline, error) {
 	raw, err := os.ReadFile(path)
 	if err != nil {
@@ -82,6 +91,10 @@ func Load(path string) (*Pipeline, error) {
 		return nil, err
 	}
 
+	if p.Extends != "" {
+		return loadExtended(path, &p)
+	}
+
 	if len(p.Modules) == 0 {
 		return nil, fmt.Errorf("pipeline has no modules")
 	}
@@ -89,62 +102,43 @@ func Load(path string) (*Pipeline, error) {
 	return &p, nil
 }
 
-// Run executes the pipeline
-func Run(ctx context.Context, p *Pipeline, binDir string) error {
-	runDir, err := os.MkdirTemp("", "opentrace-run-*")
-	if err != nil {
-		return err
+// asArtifactRef attempts to interpret raw as an artifact reference, as
+// opposed to a literal value.
+func asArtifactRef(raw any) (artifactRef, bool) {
+	if raw == nil {
+		return artifactRef{}, false
 	}
-
-	for _, step := range p.Modules {
-		stepDir := filepath.Join(runDir, step.Name)
-
-		if err := os.MkdirAll(stepDir, 0o755); err != nil {
-			return err
-		}
-
-		input, err := resolveInput(runDir, step.Input)
-		if err != nil {
-			return fmt.Errorf("[%s] input resolution failed: %w", step.Name, err)
-		}
-
-		if err := runModule(
-			ctx,
-			filepath.Join(binDir, step.Name),
-			input,
-			step.Config,
-			runDir,
-			stepDir,
-		); err != nil {
-			return fmt.Errorf("[%s] %w", step.Name, err)
-		}
+	if _, ok := raw.(string); ok {
+		return artifactRef{}, false
 	}
 
-	return nil
+	b, err := yaml.Marshal(raw)
+	if err != nil {
+		return artifactRef{}, false
+	}
+	var ref artifactRef
+	if err := yaml.Unmarshal(b, &ref); err != nil {
+		return artifactRef{}, false
+	}
+	if ref.From == "" || ref.Artifact == "" {
+		return artifactRef{}, false
+	}
+	return ref, true
 }
 
-// resolveInput converts pipeline input into a literal or absolute artifact path
+// resolveInput converts a literal or artifact reference into a literal
+// string or an absolute artifact path.
 func resolveInput(runDir string, raw any) (string, error) {
 	if raw == nil {
 		return "", nil
 	}
 
-	// Literal string
 	if v, ok := raw.(string); ok {
 		return v, nil
 	}
 
-	// Artifact reference
-	var ref artifactRef
-	b, err := yaml.Marshal(raw)
-	if err != nil {
-		return "", err
-	}
-	if err := yaml.Unmarshal(b, &ref); err != nil {
-		return "", err
-	}
-
-	if ref.From == "" || ref.Artifact == "" {
+	ref, ok := asArtifactRef(raw)
+	if !ok {
 		return "", fmt.Errorf("invalid artifact reference")
 	}
 
@@ -170,35 +164,3 @@ func resolveInput(runDir string, raw any) (string, error) {
 
 	return filepath.Join(runDir, ref.From, art.Path), nil
 }
-
-// runModule executes a module binary
-func runModule(
-	ctx context.Context,
-	binPath string,
-	input string,
-	config map[string]any,
-	runDir string,
-	stepDir string,
-) error {
-
-	payload := map[string]any{
-		"input":  input,
-		"config": config,
-	}
-
-	raw, err := json.Marshal(payload)
-	if err != nil {
-		return err
-	}
-
-	cmd := exec.CommandContext(ctx, binPath)
-	cmd.Stdin = bytes.NewReader(raw)
-	cmd.Stdout = nil           // stdout is ignored by design
-	cmd.Stderr = os.Stderr    // operator UX
-	cmd.Env = append(os.Environ(),
-		"OPENTRACE_RUN_DIR="+runDir,
-		"OPENTRACE_STEP_DIR="+stepDir,
-	)
-
-	return cmd.Run()
-}
\ No newline at end of file
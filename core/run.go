@@ -0,0 +1,177 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// Run executes the pipeline as a DAG: steps within a wave have no
+// dependency on each other and run concurrently across a worker pool
+// bounded by jobs (runtime.NumCPU() when jobs <= 0). The whole run is
+// cancelled on the first step that returns a non-zero exit.
+func Run(ctx context.Context, p *Pipeline, binDir string, jobs int) error {
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+
+	graph, err := buildGraph(p)
+	if err != nil {
+		return err
+	}
+	waves, err := topoOrder(graph)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]Step, len(p.Modules))
+	for _, s := range p.Modules {
+		byName[s.Name] = s
+	}
+
+	runDir, err := os.MkdirTemp("", "opentrace-run-*")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, jobs)
+
+	for _, wave := range waves {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		errCh := make(chan error, len(wave))
+
+		for _, name := range wave {
+			step := byName[name]
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				defer func() { <-sem }()
+
+				if ctx.Err() != nil {
+					return
+				}
+				if err := runStep(ctx, step, binDir, runDir); err != nil {
+					errCh <- err
+					cancel()
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(errCh)
+
+		if err, ok := <-errCh; ok {
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runStep resolves a step's inputs and executes its module binary.
+func runStep(ctx context.Context, step Step, binDir, runDir string) error {
+	stepDir := filepath.Join(runDir, step.Name)
+
+	if err := os.MkdirAll(stepDir, 0o755); err != nil {
+		return fmt.Errorf("[%s] %w", step.Name, err)
+	}
+
+	input, inputs, err := resolveInputs(runDir, step)
+	if err != nil {
+		return fmt.Errorf("[%s] input resolution failed: %w", step.Name, err)
+	}
+
+	if err := runModule(
+		ctx,
+		filepath.Join(binDir, step.Name),
+		input,
+		inputs,
+		step.Config,
+		runDir,
+		stepDir,
+	); err != nil {
+		return fmt.Errorf("[%s] %w", step.Name, err)
+	}
+
+	return nil
+}
+
+// resolveInputs resolves a step's single Input and its aliased Inputs.
+func resolveInputs(runDir string, step Step) (string, map[string]string, error) {
+	input, err := resolveInput(runDir, step.Input)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var inputs map[string]string
+	if len(step.Inputs) > 0 {
+		inputs = make(map[string]string, len(step.Inputs))
+		for alias, raw := range step.Inputs {
+			resolved, err := resolveInput(runDir, raw)
+			if err != nil {
+				return "", nil, fmt.Errorf("input %q: %w", alias, err)
+			}
+			inputs[alias] = resolved
+		}
+	}
+
+	return input, inputs, nil
+}
+
+// runModule executes a module binary
+func runModule(
+	ctx context.Context,
+	binPath string,
+	input string,
+	inputs map[string]string,
+	config map[string]any,
+	runDir string,
+	stepDir string,
+) error {
+
+	payload := map[string]any{
+		"input":  input,
+		"inputs": inputs,
+		"config": config,
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, binPath)
+	cmd.Stdin = bytes.NewReader(raw)
+	cmd.Stdout = nil           // stdout is ignored by design
+	cmd.Stderr = os.Stderr    // operator UX
+	cmd.Env = append(os.Environ(),
+		"OPENTRACE_RUN_DIR="+runDir,
+		"OPENTRACE_STEP_DIR="+stepDir,
+	)
+
+	return cmd.Run()
+}
@@ -0,0 +1,247 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/its-ernest/opentrace/installer"
+	"gopkg.in/yaml.v3"
+)
+
+// VarSpec describes one variable a template accepts via a consuming
+// pipeline's `with:`.
+type VarSpec struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"` // string, int, bool, float — informational, substitution is always textual
+	Default  any    `yaml:"default"`
+	Required bool   `yaml:"required"`
+}
+
+// Template is a reusable pipeline body declared with `kind: template`.
+type Template struct {
+	Kind    string    `yaml:"kind"`
+	Vars    []VarSpec `yaml:"vars"`
+	Modules []Step    `yaml:"modules"`
+}
+
+// LoadTemplate parses a template YAML file.
+func LoadTemplate(path string) (*Template, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var t Template
+	if err := yaml.Unmarshal(raw, &t); err != nil {
+		return nil, err
+	}
+
+	if t.Kind != "template" {
+		return nil, fmt.Errorf("%s is not a template (kind: %q, want \"template\")", path, t.Kind)
+	}
+	if len(t.Modules) == 0 {
+		return nil, fmt.Errorf("template has no modules")
+	}
+
+	return &t, nil
+}
+
+// loadExtended resolves a pipeline's `extends:` reference into a template,
+// validates `with` against the template's vars schema, and renders the
+// template's ${var} placeholders into a concrete, runnable Pipeline.
+func loadExtended(path string, p *Pipeline) (*Pipeline, error) {
+	templatePath, cleanup, err := fetchTemplate(path, p.Extends)
+	if err != nil {
+		return nil, fmt.Errorf("extends %q: %w", p.Extends, err)
+	}
+	defer cleanup()
+
+	t, err := LoadTemplate(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("extends %q: %w", p.Extends, err)
+	}
+
+	vars, err := resolveVars(t, p.With)
+	if err != nil {
+		return nil, fmt.Errorf("extends %q: %w", p.Extends, err)
+	}
+
+	return render(t, vars)
+}
+
+// noopCleanup is returned alongside a path fetchTemplate didn't stage itself,
+// so callers can unconditionally defer the cleanup it returns.
+func noopCleanup() {}
+
+// fetchTemplate resolves an `extends:` reference into a local file path: a
+// path relative to the consuming pipeline, or a "host/owner/repo#sub/path"
+// reference cloned via the installer's cache. The returned cleanup func
+// removes any file fetchTemplate staged on disk and must be called once the
+// caller is done reading the path.
+func fetchTemplate(pipelinePath, ref string) (string, func(), error) {
+	if repo, subPath, ok := strings.Cut(ref, "#"); ok {
+		return fetchRemoteTemplate(repo, subPath)
+	}
+
+	if filepath.IsAbs(ref) {
+		return ref, noopCleanup, nil
+	}
+	return filepath.Join(filepath.Dir(pipelinePath), ref), noopCleanup, nil
+}
+
+// fetchRemoteTemplate pulls a template out of repo's persistent cache and
+// stages it as a temp file LoadTemplate can read. The caller must invoke the
+// returned cleanup func to remove that temp file once done with it.
+func fetchRemoteTemplate(repo, subPath string) (string, func(), error) {
+	repoURL := repo
+	if !strings.HasPrefix(repoURL, "http://") && !strings.HasPrefix(repoURL, "https://") {
+		repoURL = "https://" + repoURL
+	}
+
+	content, err := installer.FetchFile(repoURL, subPath)
+	if err != nil {
+		return "", noopCleanup, err
+	}
+
+	tmp, err := os.CreateTemp("", "opentrace-template-*.yaml")
+	if err != nil {
+		return "", noopCleanup, err
+	}
+	defer tmp.Close()
+
+	if _, err := tmp.Write(content); err != nil {
+		os.Remove(tmp.Name())
+		return "", noopCleanup, err
+	}
+
+	cleanup := func() { os.Remove(tmp.Name()) }
+	return tmp.Name(), cleanup, nil
+}
+
+// resolveVars validates `with` against a template's vars schema, filling in
+// defaults, and returns the resolved set as strings ready for substitution.
+func resolveVars(t *Template, with map[string]any) (map[string]string, error) {
+	resolved := make(map[string]string, len(t.Vars))
+
+	for _, v := range t.Vars {
+		val, provided := with[v.Name]
+		if !provided {
+			if v.Required {
+				return nil, fmt.Errorf("template variable %q is required", v.Name)
+			}
+			val = v.Default
+		}
+		resolved[v.Name] = fmt.Sprint(val)
+	}
+
+	for name := range with {
+		if !varDeclared(t.Vars, name) {
+			return nil, fmt.Errorf("unknown template variable %q", name)
+		}
+	}
+
+	return resolved, nil
+}
+
+func varDeclared(vars []VarSpec, name string) bool {
+	for _, v := range vars {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+var placeholder = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// render substitutes ${var} placeholders in every Step.Input/Step.Inputs/
+// Step.Config string with vars, returning a concrete, runnable Pipeline.
+func render(t *Template, vars map[string]string) (*Pipeline, error) {
+	modules := make([]Step, len(t.Modules))
+	for i, s := range t.Modules {
+		input, err := renderAny(s.Input, vars)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] input: %w", s.Name, err)
+		}
+		s.Input = input
+
+		if s.Inputs != nil {
+			inputs := make(map[string]any, len(s.Inputs))
+			for alias, raw := range s.Inputs {
+				rendered, err := renderAny(raw, vars)
+				if err != nil {
+					return nil, fmt.Errorf("[%s] inputs.%s: %w", s.Name, alias, err)
+				}
+				inputs[alias] = rendered
+			}
+			s.Inputs = inputs
+		}
+
+		if s.Config != nil {
+			config := make(map[string]any, len(s.Config))
+			for key, raw := range s.Config {
+				rendered, err := renderAny(raw, vars)
+				if err != nil {
+					return nil, fmt.Errorf("[%s] config.%s: %w", s.Name, key, err)
+				}
+				config[key] = rendered
+			}
+			s.Config = config
+		}
+
+		modules[i] = s
+	}
+
+	return &Pipeline{Modules: modules}, nil
+}
+
+// renderAny walks a YAML-decoded value substituting ${var} placeholders in
+// every string it finds, recursing into nested maps and slices.
+func renderAny(raw any, vars map[string]string) (any, error) {
+	switch v := raw.(type) {
+	case string:
+		return renderString(v, vars)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			r, err := renderAny(val, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = r
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			r, err := renderAny(val, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = r
+		}
+		return out, nil
+	default:
+		return raw, nil
+	}
+}
+
+func renderString(s string, vars map[string]string) (string, error) {
+	var missing []string
+	rendered := placeholder.ReplaceAllStringFunc(s, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		val, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return val
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined template variable(s): %s", strings.Join(missing, ", "))
+	}
+	return rendered, nil
+}
@@ -0,0 +1,72 @@
+package core
+
+import "testing"
+
+func TestRenderString(t *testing.T) {
+	vars := map[string]string{"name": "alice", "limit": "10"}
+
+	got, err := renderString("hello ${name}, limit is ${limit}", vars)
+	if err != nil {
+		t.Fatalf("renderString returned error: %v", err)
+	}
+	if got != "hello alice, limit is 10" {
+		t.Errorf("renderString = %q, want %q", got, "hello alice, limit is 10")
+	}
+}
+
+func TestRenderStringUndefined(t *testing.T) {
+	if _, err := renderString("hello ${missing}", map[string]string{}); err == nil {
+		t.Error("expected error for undefined template variable, got nil")
+	}
+}
+
+func TestRenderStringNoPlaceholders(t *testing.T) {
+	got, err := renderString("plain string", nil)
+	if err != nil {
+		t.Fatalf("renderString returned error: %v", err)
+	}
+	if got != "plain string" {
+		t.Errorf("renderString = %q, want unchanged string", got)
+	}
+}
+
+func TestResolveVarsDefaultsAndRequired(t *testing.T) {
+	tmpl := &Template{
+		Kind: "template",
+		Vars: []VarSpec{
+			{Name: "required", Required: true},
+			{Name: "optional", Default: "fallback"},
+		},
+		Modules: []Step{{Name: "a"}},
+	}
+
+	vars, err := resolveVars(tmpl, map[string]any{"required": "value"})
+	if err != nil {
+		t.Fatalf("resolveVars returned error: %v", err)
+	}
+	if vars["required"] != "value" || vars["optional"] != "fallback" {
+		t.Errorf("resolveVars = %+v, want required=value optional=fallback", vars)
+	}
+}
+
+func TestResolveVarsMissingRequired(t *testing.T) {
+	tmpl := &Template{
+		Kind:    "template",
+		Vars:    []VarSpec{{Name: "required", Required: true}},
+		Modules: []Step{{Name: "a"}},
+	}
+	if _, err := resolveVars(tmpl, map[string]any{}); err == nil {
+		t.Error("expected error for missing required variable, got nil")
+	}
+}
+
+func TestResolveVarsUnknown(t *testing.T) {
+	tmpl := &Template{
+		Kind:    "template",
+		Vars:    []VarSpec{{Name: "known"}},
+		Modules: []Step{{Name: "a"}},
+	}
+	if _, err := resolveVars(tmpl, map[string]any{"unknown": "value"}); err == nil {
+		t.Error("expected error for undeclared variable, got nil")
+	}
+}
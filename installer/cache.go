@@ -0,0 +1,127 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// cacheRoot returns the directory under which persistent bare clones live,
+// keyed by host/owner/repo so distinct repos never collide.
+func cacheRoot() string { return filepath.Join(home(), ".opentrace", "cache") }
+
+// cachePath returns the bare-clone path for a parsed repo spec.
+func cachePath(host, owner, repo string) string {
+	return filepath.Join(cacheRoot(), host, owner, repo+".git")
+}
+
+// isRemoteRepo reports whether url points at a git remote rather than a
+// local/linked module's filesystem directory (see MergeLocal, which stamps
+// RepoURL with the on-disk path for those). Only remote repos have anything
+// for ensureCache to clone or fetch.
+func isRemoteRepo(url string) bool {
+	return strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")
+}
+
+// parseRepoSpec splits a repo URL into host/owner/repo, e.g.
+// "https://github.com/its-ernest/opentrace-modules" → ("github.com", "its-ernest", "opentrace-modules").
+func parseRepoSpec(repoURL string) (host, owner, repo string, err error) {
+	u := strings.TrimSuffix(repoURL, ".git")
+	u = strings.TrimPrefix(u, "https://")
+	u = strings.TrimPrefix(u, "http://")
+	parts := strings.Split(u, "/")
+	if len(parts) < 3 {
+		return "", "", "", fmt.Errorf("cannot parse repo url %q", repoURL)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// ensureCache makes sure a persistent mirror clone of repoURL exists and is
+// up to date, cloning it on first use and fetching on every subsequent call.
+// A plain `git clone --bare` does not configure a fetch refspec, so a later
+// `fetch` would only ever pull tags/FETCH_HEAD and never advance refs/heads —
+// `--mirror` configures remote.origin.fetch = +refs/*:refs/* so HEAD and every
+// branch actually move on fetch.
+func ensureCache(repoURL string) (string, error) {
+	host, owner, repo, err := parseRepoSpec(repoURL)
+	if err != nil {
+		return "", err
+	}
+	path := cachePath(host, owner, repo)
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return "", err
+		}
+		fmt.Printf("  cloning %s into cache...\n", repoURL)
+		if out, err := exec.Command("git", "clone", "--mirror", repoURL, path).CombinedOutput(); err != nil {
+			return "", fmt.Errorf("git clone --mirror: %s: %w", string(out), err)
+		}
+		return path, nil
+	}
+
+	if out, err := fetchCache(path); err != nil {
+		return "", fmt.Errorf("git fetch: %s: %w", string(out), err)
+	}
+	return path, nil
+}
+
+// fetchCache refreshes a cached mirror clone against its remote.
+func fetchCache(cache string) ([]byte, error) {
+	cmd := exec.Command("git", "--git-dir", cache, "fetch", "--prune")
+	out, err := cmd.CombinedOutput()
+	return out, err
+}
+
+// addWorktree materializes ref from cache into a fresh temp directory.
+func addWorktree(cache, ref string) (string, error) {
+	wt, err := os.MkdirTemp("", "opentrace-wt-*")
+	if err != nil {
+		return "", err
+	}
+	if out, err := exec.Command("git", "--git-dir", cache,
+		"worktree", "add", "--detach", wt, ref,
+	).CombinedOutput(); err != nil {
+		os.RemoveAll(wt)
+		return "", fmt.Errorf("git worktree add: %s: %w", string(out), err)
+	}
+	return wt, nil
+}
+
+// removeWorktree tears down a worktree created by addWorktree and prunes its
+// bookkeeping from the cache so future worktree adds stay clean.
+func removeWorktree(cache, wt string) {
+	_, _ = exec.Command("git", "--git-dir", cache, "worktree", "remove", "--force", wt).CombinedOutput()
+	os.RemoveAll(wt)
+	_, _ = exec.Command("git", "--git-dir", cache, "worktree", "prune").CombinedOutput()
+}
+
+// FetchFile fetches subPath out of repoURL's persistent cache at the current
+// default ref, returning its contents. Used by core to pull pipeline
+// templates out of a git repo, reusing the cached mirror clone instead of
+// cloning the repo afresh on every fetch.
+func FetchFile(repoURL, subPath string) ([]byte, error) {
+	cache, err := ensureCache(repoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	wt, err := addWorktree(cache, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	defer removeWorktree(cache, wt)
+
+	return os.ReadFile(filepath.Join(wt, subPath))
+}
+
+// resolveSHA returns the commit SHA that ref currently points at in cache.
+func resolveSHA(cache, ref string) (string, error) {
+	out, err := exec.Command("git", "--git-dir", cache, "rev-parse", ref).Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse %s: %w", ref, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -26,14 +26,19 @@ type Manifest struct {
 	Verified    bool     `yaml:"verified"`
 	EntityTypes []string `yaml:"entity_types"`
 	Repo        string   `yaml:"repo"`
+	PublicKey   string   `yaml:"public_key"` // fingerprint of the signing key, trusted via `opentrace keys add`
 }
 
 type RegistryEntry struct {
-	BinPath  string `json:"bin_path"`
-	Version  string `json:"version"`
-	Author   string `json:"author"`
-	Official bool   `json:"official"`
-	Verified bool   `json:"verified"`
+	BinPath    string `json:"bin_path"`
+	Version    string `json:"version"`
+	Author     string `json:"author"`
+	Official   bool   `json:"official"`
+	Verified   bool   `json:"verified"`
+	RepoURL    string `json:"repo_url"`             // cache key for `opentrace update`
+	SubPath    string `json:"sub_path,omitempty"`   // path within the repo to the module (official only)
+	Commit     string `json:"commit"`               // resolved SHA at install/update time
+	Constraint string `json:"constraint,omitempty"` // version constraint pinned at install time, e.g. "^1.2" or "1.2.x" (official only)
 }
 
 type Registry map[string]RegistryEntry
@@ -42,13 +47,23 @@ func home() string         { h, _ := os.UserHomeDir(); return h }
 func BinDir() string       { return filepath.Join(home(), ".opentrace", "bin") }
 func registryPath() string { return filepath.Join(home(), ".opentrace", "registry.json") }
 
+// LoadRegistry loads installed modules from disk, then overlays any local
+// modules discovered under ModulesPath() and any `opentrace link`ed modules —
+// both are rebuilt here if their source changed, so callers always see the
+// current binary without a separate "refresh" step.
 func LoadRegistry() Registry {
 	r := Registry{}
-	data, err := os.ReadFile(registryPath())
-	if err != nil {
-		return r
+	if data, err := os.ReadFile(registryPath()); err == nil {
+		_ = json.Unmarshal(data, &r)
+	}
+
+	if local, err := DiscoverLocal(ModulesPath()); err == nil {
+		r = MergeLocal(r, local)
 	}
-	_ = json.Unmarshal(data, &r)
+	if linked, err := discoverLinked(); err == nil {
+		r = MergeLocal(r, linked)
+	}
+
 	return r
 }
 
@@ -60,15 +75,17 @@ func saveRegistry(r Registry) error {
 
 // Install is the single entry point.
 // Detects whether the argument is a name (official) or a repo path (external).
-func Install(arg string) error {
+// allowUntrusted lets an install proceed when the manifest's signature cannot
+// be verified against a trusted key (--allow-untrusted on the CLI).
+func Install(arg string, allowUntrusted bool) error {
 	if err := os.MkdirAll(BinDir(), 0o755); err != nil {
 		return fmt.Errorf("mkdir: %w", err)
 	}
 
 	if isExternalRepo(arg) {
-		return installExternal(arg)
+		return installExternal(arg, allowUntrusted)
 	}
-	return installOfficial(arg)
+	return installOfficial(arg, allowUntrusted)
 }
 
 // isExternalRepo returns true if the argument looks like a repo path.
@@ -77,57 +94,60 @@ func isExternalRepo(arg string) bool {
 	return strings.Contains(arg, "/")
 }
 
-// installOfficial fetches from opentrace-modules using sparse checkout.
-func installOfficial(name string) error {
-	tmp, err := os.MkdirTemp("", "opentrace-*")
+// installOfficial resolves a module out of the persistent opentrace-modules
+// cache. name may carry a version constraint, e.g. "geoip@1.2.x" or "geoip@^1.2".
+func installOfficial(name string, allowUntrusted bool) error {
+	name, constraint := splitConstraint(name)
+
+	cache, err := ensureCache(modulesRepo)
 	if err != nil {
 		return err
 	}
-	defer os.RemoveAll(tmp)
-
-	fmt.Printf("  fetching %s from opentrace-modules...\n", name)
-
-	sparseDir := filepath.Join(modulesPrefix, name)
-
-	if out, err := exec.Command("git", "clone",
-		"--depth=1", "--filter=blob:none", "--sparse",
-		modulesRepo, tmp,
-	).CombinedOutput(); err != nil {
-		return fmt.Errorf("git clone: %s: %w", string(out), err)
-	}
 
-	if out, err := exec.Command("git", "-C", tmp,
-		"sparse-checkout", "set", sparseDir,
-	).CombinedOutput(); err != nil {
-		return fmt.Errorf("sparse-checkout: %s: %w", string(out), err)
+	wt, err := addWorktree(cache, "HEAD")
+	if err != nil {
+		return err
 	}
+	defer removeWorktree(cache, wt)
 
-	moduleDir := filepath.Join(tmp, modulesPrefix, name)
+	subPath := filepath.Join(modulesPrefix, name)
+	moduleDir := filepath.Join(wt, subPath)
 	if _, err := os.Stat(moduleDir); os.IsNotExist(err) {
 		return fmt.Errorf("module %q not found in opentrace-modules", name)
 	}
 
-	version, err := latestVersion(moduleDir)
+	version, err := latestVersion(moduleDir, constraint)
 	if err != nil {
 		return fmt.Errorf("no versions found for %q: %w", name, err)
 	}
 
 	srcDir := filepath.Join(moduleDir, version)
+	manifestPath := filepath.Join(srcDir, "manifest.yaml")
 
-	manifest, err := readManifest(filepath.Join(srcDir, "manifest.yaml"))
+	manifest, err := readManifest(manifestPath)
 	if err != nil {
 		return fmt.Errorf("manifest: %w", err)
 	}
 
 	printManifest(manifest)
 
-	// official modules are always verified — no prompt needed
-	return build(name, version, srcDir, manifest, true)
+	manifest.Verified, err = verifyOrAbort(manifestPath, manifest, allowUntrusted)
+	if err != nil {
+		return err
+	}
+
+	commit, err := resolveSHA(cache, "HEAD")
+	if err != nil {
+		return err
+	}
+
+	return build(name, version, srcDir, manifest, true, modulesRepo, subPath, constraint, commit)
 }
 
-// installExternal clones a community repo, reads its manifest, prompts if unverified.
-// arg is the full repo path e.g. github.com/alice/opentrace-face-osint
-func installExternal(arg string) error {
+// installExternal resolves a community repo out of its own persistent cache,
+// reads its manifest and verifies its signature. arg is the full repo path
+// e.g. github.com/alice/opentrace-face-osint
+func installExternal(arg string, allowUntrusted bool) error {
 	// derive module name from last path segment
 	// github.com/alice/opentrace-face-osint → opentrace-face-osint
 	// then strip opentrace- prefix if present for the bin name
@@ -140,22 +160,20 @@ func installExternal(arg string) error {
 		repoURL = arg
 	}
 
-	tmp, err := os.MkdirTemp("", "opentrace-*")
+	cache, err := ensureCache(repoURL)
 	if err != nil {
 		return err
 	}
-	defer os.RemoveAll(tmp)
-
-	fmt.Printf("  fetching %s...\n", arg)
 
-	if out, err := exec.Command("git", "clone",
-		"--depth=1", repoURL, tmp,
-	).CombinedOutput(); err != nil {
-		return fmt.Errorf("git clone: %s: %w", string(out), err)
+	wt, err := addWorktree(cache, "HEAD")
+	if err != nil {
+		return err
 	}
+	defer removeWorktree(cache, wt)
 
 	// manifest must be at root of the repo
-	manifest, err := readManifest(filepath.Join(tmp, "manifest.yaml"))
+	manifestPath := filepath.Join(wt, "manifest.yaml")
+	manifest, err := readManifest(manifestPath)
 	if err != nil {
 		return fmt.Errorf("manifest: %w", err)
 	}
@@ -167,22 +185,43 @@ func installExternal(arg string) error {
 
 	printManifest(manifest)
 
-	// external repos are always unverified unless explicitly marked
-	if !manifest.Verified {
-		fmt.Printf("  ⚠  %s is unverified (community module). Install anyway? (y/n): ", name)
-		var confirm string
-		fmt.Scan(&confirm)
-		if confirm != "y" {
-			fmt.Println("  aborted.")
-			return nil
-		}
+	manifest.Verified, err = verifyOrAbort(manifestPath, manifest, allowUntrusted)
+	if err != nil {
+		return err
+	}
+
+	commit, err := resolveSHA(cache, "HEAD")
+	if err != nil {
+		return err
+	}
+
+	return build(name, manifest.Version, wt, manifest, false, repoURL, "", "", commit)
+}
+
+// verifyOrAbort verifies a manifest's detached signature and reports whether
+// it should be marked Verified. A failed verification — including a missing
+// public_key, official or not — aborts the install unless allowUntrusted is
+// set, in which case the module is installed unverified with a loud warning.
+func verifyOrAbort(manifestPath string, manifest *Manifest, allowUntrusted bool) (bool, error) {
+	ok, err := verifyManifest(manifestPath, manifest)
+	if ok {
+		fmt.Printf("  ✓ signature verified against key %s\n", manifest.PublicKey)
+		return true, nil
 	}
 
-	return build(name, manifest.Version, tmp, manifest, false)
+	if !allowUntrusted {
+		return false, fmt.Errorf("signature verification failed: %w (pass --allow-untrusted to install anyway)", err)
+	}
+
+	fmt.Printf("  ⚠  %s: signature verification failed (%v) — installing unverified\n", manifest.Name, err)
+	return false, nil
 }
 
-// build compiles the module source and registers it.
-func build(name, version, srcDir string, manifest *Manifest, official bool) error {
+// build compiles the module source and registers it. constraint is the
+// version constraint the user pinned at install time (official modules
+// only, "" otherwise), persisted so `opentrace update` re-resolves against
+// the same constraint instead of silently jumping past it.
+func build(name, version, srcDir string, manifest *Manifest, official bool, repoURL, subPath, constraint, commit string) error {
 	binName := name
 	if runtime.GOOS == "windows" {
 		binName += ".exe"
@@ -196,17 +235,21 @@ func build(name, version, srcDir string, manifest *Manifest, official bool) erro
 
 	reg := LoadRegistry()
 	reg[name] = RegistryEntry{
-		BinPath:  binPath,
-		Version:  manifest.Version,
-		Author:   manifest.Author,
-		Official: official,
-		Verified: manifest.Verified,
+		BinPath:    binPath,
+		Version:    manifest.Version,
+		Author:     manifest.Author,
+		Official:   official,
+		Verified:   manifest.Verified,
+		RepoURL:    repoURL,
+		SubPath:    subPath,
+		Constraint: constraint,
+		Commit:     commit,
 	}
 	if err := saveRegistry(reg); err != nil {
 		return fmt.Errorf("save registry: %w", err)
 	}
 
-	fmt.Printf("  ✓ %s@%s installed → %s\n", name, version, binPath)
+	fmt.Printf("  ✓ %s@%s installed → %s (%s)\n", name, version, binPath, shortSHA(commit))
 	return nil
 }
 
@@ -228,9 +271,9 @@ func List() {
 		return
 	}
 	fmt.Println()
-	fmt.Printf("  %-22s  %-10s  %-16s  %s\n", "MODULE", "VERSION", "AUTHOR", "STATUS")
-	fmt.Printf("  %-22s  %-10s  %-16s  %s\n",
-		"──────────────────────", "─────────", "───────────────", "──────────")
+	fmt.Printf("  %-22s  %-10s  %-16s  %-10s  %s\n", "MODULE", "VERSION", "AUTHOR", "COMMIT", "STATUS")
+	fmt.Printf("  %-22s  %-10s  %-16s  %-10s  %s\n",
+		"──────────────────────", "─────────", "───────────────", "──────────", "──────────")
 	for name, entry := range reg {
 		status := "unverified"
 		if entry.Official {
@@ -238,8 +281,8 @@ func List() {
 		} else if entry.Verified {
 			status = "verified"
 		}
-		fmt.Printf("  %-22s  %-10s  %-16s  %s\n",
-			name, entry.Version, entry.Author, status)
+		fmt.Printf("  %-22s  %-10s  %-16s  %-10s  %s\n",
+			name, entry.Version, entry.Author, shortSHA(entry.Commit), status)
 	}
 	fmt.Println()
 }
@@ -266,7 +309,9 @@ func printManifest(m *Manifest) {
 	fmt.Println()
 }
 
-func latestVersion(moduleDir string) (string, error) {
+// latestVersion picks the highest semver-valid version directory under
+// moduleDir that satisfies constraint ("" meaning the newest overall).
+func latestVersion(moduleDir string, constraint string) (string, error) {
 	entries, err := os.ReadDir(moduleDir)
 	if err != nil {
 		return "", err
@@ -280,7 +325,16 @@ func latestVersion(moduleDir string) (string, error) {
 	if len(versions) == 0 {
 		return "", fmt.Errorf("no version directories found")
 	}
-	return versions[len(versions)-1], nil
+	return MatchVersion(versions, constraint)
+}
+
+// splitConstraint separates a "module@constraint" argument into its name and
+// constraint parts. constraint is "" when no "@" is present.
+func splitConstraint(arg string) (name, constraint string) {
+	if i := strings.LastIndex(arg, "@"); i >= 0 {
+		return arg[:i], arg[i+1:]
+	}
+	return arg, ""
 }
 
 func readManifest(path string) (*Manifest, error) {
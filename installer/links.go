@@ -0,0 +1,96 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func linksPath() string { return filepath.Join(home(), ".opentrace", "links.json") }
+
+// links maps module name to the source directory it was linked from.
+type links map[string]string
+
+func loadLinks() links {
+	l := links{}
+	data, err := os.ReadFile(linksPath())
+	if err != nil {
+		return l
+	}
+	_ = json.Unmarshal(data, &l)
+	return l
+}
+
+func saveLinks(l links) error {
+	_ = os.MkdirAll(filepath.Dir(linksPath()), 0o755)
+	data, _ := json.MarshalIndent(l, "", "  ")
+	return os.WriteFile(linksPath(), data, 0o644)
+}
+
+// Link registers dir as a live-reloaded local module: every LoadRegistry call
+// (and therefore every `opentrace run`) rebuilds it if its Go source changed
+// since the last build, so authors can iterate on a manifest/module without a
+// git round-trip.
+func Link(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := readManifest(filepath.Join(abs, "manifest.yaml"))
+	if err != nil {
+		return fmt.Errorf("manifest: %w", err)
+	}
+
+	if err := buildLocal(manifest.Name, abs); err != nil {
+		return err
+	}
+
+	l := loadLinks()
+	l[manifest.Name] = abs
+	if err := saveLinks(l); err != nil {
+		return err
+	}
+
+	fmt.Printf("  ✓ linked %s → %s (rebuilt on run when its source changes)\n", manifest.Name, abs)
+	return nil
+}
+
+// Unlink removes a module registered with Link and its built binary.
+func Unlink(name string) error {
+	l := loadLinks()
+	if _, ok := l[name]; !ok {
+		return fmt.Errorf("module %q is not linked", name)
+	}
+	delete(l, name)
+	if err := saveLinks(l); err != nil {
+		return err
+	}
+
+	binPath := binPathFor(name)
+	_ = os.Remove(binPath)
+	_ = os.Remove(binPath + ".srchash")
+
+	fmt.Printf("  ✓ unlinked %s\n", name)
+	return nil
+}
+
+// discoverLinked rebuilds (if needed) and returns every module registered
+// with Link.
+func discoverLinked() ([]LocalEntry, error) {
+	var found []LocalEntry
+	for name, dir := range loadLinks() {
+		manifest, err := readManifest(filepath.Join(dir, "manifest.yaml"))
+		if err != nil {
+			fmt.Printf("  linked module %q: %v\n", name, err)
+			continue
+		}
+		if err := buildLocal(name, dir); err != nil {
+			fmt.Printf("  linked module %q: build failed: %v\n", name, err)
+			continue
+		}
+		found = append(found, LocalEntry{Name: name, Dir: dir, Manifest: manifest})
+	}
+	return found, nil
+}
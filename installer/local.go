@@ -0,0 +1,153 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const defaultPluginsDir = "plugins" // under ~/.opentrace
+
+// ModulesPath returns the directories to search for local modules:
+// OPENTRACE_MODULES_PATH if set (colon-separated), otherwise ~/.opentrace/plugins.
+func ModulesPath() []string {
+	if raw := os.Getenv("OPENTRACE_MODULES_PATH"); raw != "" {
+		return strings.Split(raw, ":")
+	}
+	return []string{filepath.Join(home(), ".opentrace", defaultPluginsDir)}
+}
+
+// LocalEntry describes a module discovered on disk via manifest.yaml, as
+// opposed to one installed from a git repo.
+type LocalEntry struct {
+	Name     string
+	Dir      string
+	Manifest *Manifest
+}
+
+// DiscoverLocal walks each directory in paths looking for subdirectories
+// containing a manifest.yaml, (re)building any whose source changed.
+// Modeled on Helm's FindPlugins(pluginsDirectory).
+func DiscoverLocal(paths []string) ([]LocalEntry, error) {
+	var found []LocalEntry
+	for _, root := range paths {
+		entries, err := os.ReadDir(root)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %q: %w", root, err)
+		}
+
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			dir := filepath.Join(root, e.Name())
+			manifestPath := filepath.Join(dir, "manifest.yaml")
+			if _, err := os.Stat(manifestPath); err != nil {
+				continue
+			}
+
+			manifest, err := readManifest(manifestPath)
+			if err != nil {
+				fmt.Printf("  %s: %v\n", dir, err)
+				continue
+			}
+
+			name := manifest.Name
+			if name == "" {
+				name = e.Name()
+			}
+
+			if err := buildLocal(name, dir); err != nil {
+				fmt.Printf("  %s: build failed: %v\n", name, err)
+				continue
+			}
+
+			found = append(found, LocalEntry{Name: name, Dir: dir, Manifest: manifest})
+		}
+	}
+	return found, nil
+}
+
+// MergeLocal folds discovered local modules into reg, overwriting any
+// existing entry of the same name — local modules take priority so authors
+// can iterate on a module without uninstalling a published version first.
+func MergeLocal(reg Registry, local []LocalEntry) Registry {
+	for _, l := range local {
+		reg[l.Name] = RegistryEntry{
+			BinPath: binPathFor(l.Name),
+			Version: l.Manifest.Version,
+			Author:  l.Manifest.Author,
+			RepoURL: l.Dir,
+		}
+	}
+	return reg
+}
+
+func binPathFor(name string) string {
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(BinDir(), name)
+}
+
+// buildLocal rebuilds a local module's binary only when its Go source
+// changed since the last build, tracked via a content hash stamped next to
+// the binary.
+func buildLocal(name, srcDir string) error {
+	binPath := binPathFor(name)
+	stampPath := binPath + ".srchash"
+
+	hash, err := hashDir(srcDir)
+	if err != nil {
+		return err
+	}
+	if prev, err := os.ReadFile(stampPath); err == nil && string(prev) == hash {
+		return nil // up to date
+	}
+
+	if err := os.MkdirAll(BinDir(), 0o755); err != nil {
+		return err
+	}
+
+	fmt.Printf("  building %s (local)...\n", name)
+	if out, err := exec.Command("go", "build", "-o", binPath, srcDir).CombinedOutput(); err != nil {
+		return fmt.Errorf("build failed: %s: %w", string(out), err)
+	}
+
+	return os.WriteFile(stampPath, []byte(hash), 0o644)
+}
+
+// hashDir fingerprints a module's Go source so rebuilds can be skipped when
+// nothing changed.
+func hashDir(dir string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".go" {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		fmt.Fprintln(h, path)
+		_, err = io.Copy(h, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,137 @@
+package installer
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const manifestSigExt = ".minisig"
+
+// fingerprintPattern constrains trusted-key fingerprints to a safe charset so
+// one can never be used to escape trustedKeysDir() via "../" or an absolute path.
+var fingerprintPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+func validateFingerprint(fingerprint string) error {
+	if fingerprint == "" || !fingerprintPattern.MatchString(fingerprint) {
+		return fmt.Errorf("invalid key fingerprint %q", fingerprint)
+	}
+	return nil
+}
+
+func trustedKeysDir() string { return filepath.Join(home(), ".opentrace", "trusted_keys") }
+
+// rootKeys are bundled, compiled-in keys trusted for official opentrace-modules
+// releases, keyed by fingerprint. Populate before cutting a release.
+var rootKeys = map[string]ed25519.PublicKey{}
+
+// AddTrustedKey reads a raw ed25519 public key (hex- or base64-encoded) from
+// keyPath and trusts it under fingerprint.
+func AddTrustedKey(fingerprint, keyPath string) error {
+	if err := validateFingerprint(fingerprint); err != nil {
+		return err
+	}
+	raw, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("read key: %w", err)
+	}
+	if _, err := decodeKey(string(raw)); err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if err := os.MkdirAll(trustedKeysDir(), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(trustedKeysDir(), fingerprint+".pub"), raw, 0o644)
+}
+
+// ListTrustedKeys returns the fingerprints of all keys the user has trusted.
+func ListTrustedKeys() ([]string, error) {
+	entries, err := os.ReadDir(trustedKeysDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var fingerprints []string
+	for _, e := range entries {
+		fingerprints = append(fingerprints, strings.TrimSuffix(e.Name(), ".pub"))
+	}
+	return fingerprints, nil
+}
+
+// RemoveTrustedKey untrusts a previously added key.
+func RemoveTrustedKey(fingerprint string) error {
+	if err := validateFingerprint(fingerprint); err != nil {
+		return err
+	}
+	path := filepath.Join(trustedKeysDir(), fingerprint+".pub")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("key %q is not trusted", fingerprint)
+	}
+	return os.Remove(path)
+}
+
+func decodeKey(raw string) (ed25519.PublicKey, error) {
+	raw = strings.TrimSpace(raw)
+	if b, err := hex.DecodeString(raw); err == nil && len(b) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(b), nil
+	}
+	if b, err := base64.StdEncoding.DecodeString(raw); err == nil && len(b) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(b), nil
+	}
+	return nil, fmt.Errorf("key must be a %d-byte ed25519 public key, hex- or base64-encoded", ed25519.PublicKeySize)
+}
+
+func loadTrustedKey(fingerprint string) (ed25519.PublicKey, error) {
+	if k, ok := rootKeys[fingerprint]; ok {
+		return k, nil
+	}
+	if err := validateFingerprint(fingerprint); err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(filepath.Join(trustedKeysDir(), fingerprint+".pub"))
+	if err != nil {
+		return nil, fmt.Errorf("key %q is not trusted — run: opentrace keys add %s <path-to-key>", fingerprint, fingerprint)
+	}
+	return decodeKey(string(raw))
+}
+
+// verifyManifest checks manifestPath against its detached signature file
+// (manifestPath + ".minisig") using the key named by manifest.PublicKey. It
+// only returns true when the signature verifies against a trusted key.
+func verifyManifest(manifestPath string, manifest *Manifest) (bool, error) {
+	if manifest.PublicKey == "" {
+		return false, fmt.Errorf("manifest does not declare a public_key")
+	}
+
+	sigPath := manifestPath + manifestSigExt
+	sigRaw, err := os.ReadFile(sigPath)
+	if err != nil {
+		return false, fmt.Errorf("missing detached signature %s: %w", sigPath, err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sigRaw)))
+	if err != nil {
+		return false, fmt.Errorf("invalid signature encoding in %s: %w", sigPath, err)
+	}
+
+	key, err := loadTrustedKey(manifest.PublicKey)
+	if err != nil {
+		return false, err
+	}
+
+	manifestBytes, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return false, err
+	}
+
+	if !ed25519.Verify(key, manifestBytes, sig) {
+		return false, fmt.Errorf("signature does not verify against key %q", manifest.PublicKey)
+	}
+	return true, nil
+}
@@ -0,0 +1,103 @@
+package installer
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	if got, err := decodeKey(hex.EncodeToString(pub)); err != nil {
+		t.Errorf("decodeKey(hex) returned error: %v", err)
+	} else if !got.Equal(pub) {
+		t.Errorf("decodeKey(hex) = %x, want %x", got, pub)
+	}
+
+	if got, err := decodeKey(base64.StdEncoding.EncodeToString(pub)); err != nil {
+		t.Errorf("decodeKey(base64) returned error: %v", err)
+	} else if !got.Equal(pub) {
+		t.Errorf("decodeKey(base64) = %x, want %x", got, pub)
+	}
+
+	if _, err := decodeKey("not a key"); err == nil {
+		t.Error("expected error for malformed key, got nil")
+	}
+}
+
+func TestValidateFingerprint(t *testing.T) {
+	valid := []string{"abc123", "key.name-1_2"}
+	for _, fp := range valid {
+		if err := validateFingerprint(fp); err != nil {
+			t.Errorf("validateFingerprint(%q) returned error: %v", fp, err)
+		}
+	}
+
+	invalid := []string{"", "../escape", "/abs/path", "has space", "slash/in/it"}
+	for _, fp := range invalid {
+		if err := validateFingerprint(fp); err == nil {
+			t.Errorf("validateFingerprint(%q) expected error, got nil", fp)
+		}
+	}
+}
+
+func TestVerifyManifest(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	dir := t.TempDir()
+	os.Setenv("HOME", dir)
+
+	if err := os.MkdirAll(trustedKeysDir(), 0o755); err != nil {
+		t.Fatalf("mkdir trusted keys dir: %v", err)
+	}
+	fingerprint := "test-key"
+	if err := os.WriteFile(filepath.Join(trustedKeysDir(), fingerprint+".pub"), []byte(hex.EncodeToString(pub)), 0o644); err != nil {
+		t.Fatalf("write trusted key: %v", err)
+	}
+
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+	manifestBytes := []byte("name: test\nversion: 1.0.0\n")
+	if err := os.WriteFile(manifestPath, manifestBytes, 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	sig := ed25519.Sign(priv, manifestBytes)
+	sigPath := manifestPath + manifestSigExt
+	if err := os.WriteFile(sigPath, []byte(base64.StdEncoding.EncodeToString(sig)), 0o644); err != nil {
+		t.Fatalf("write signature: %v", err)
+	}
+
+	manifest := &Manifest{Name: "test", Version: "1.0.0", PublicKey: fingerprint}
+	ok, err := verifyManifest(manifestPath, manifest)
+	if err != nil {
+		t.Fatalf("verifyManifest returned error: %v", err)
+	}
+	if !ok {
+		t.Error("verifyManifest = false, want true for a validly signed manifest")
+	}
+
+	// Tamper with the manifest after signing — verification must fail.
+	if err := os.WriteFile(manifestPath, []byte("name: tampered\nversion: 1.0.0\n"), 0o644); err != nil {
+		t.Fatalf("rewrite manifest: %v", err)
+	}
+	ok, err = verifyManifest(manifestPath, manifest)
+	if ok || err == nil {
+		t.Error("verifyManifest on a tampered manifest should fail")
+	}
+}
+
+func TestVerifyManifestMissingPublicKey(t *testing.T) {
+	if _, err := verifyManifest("irrelevant.yaml", &Manifest{Name: "test"}); err == nil {
+		t.Error("expected error when manifest has no public_key, got nil")
+	}
+}
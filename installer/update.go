@@ -0,0 +1,95 @@
+package installer
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Update refreshes the given modules (or every installed module when names
+// is empty): it fetches each module's cached repo, re-resolves the newest
+// matching version against the refreshed refs, and rebuilds only the
+// modules whose resolved commit actually changed. allowUntrusted mirrors
+// Install's flag of the same name — a module whose signature no longer
+// verifies aborts the update unless it is passed.
+func Update(names []string, allowUntrusted bool) error {
+	reg := LoadRegistry()
+
+	if len(names) == 0 {
+		for name := range reg {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		entry, ok := reg[name]
+		if !ok {
+			fmt.Printf("  %s: not installed, skipping\n", name)
+			continue
+		}
+		if err := updateOne(name, entry, allowUntrusted); err != nil {
+			fmt.Printf("  %s: update failed: %v\n", name, err)
+		}
+	}
+	return nil
+}
+
+func updateOne(name string, entry RegistryEntry, allowUntrusted bool) error {
+	if !isRemoteRepo(entry.RepoURL) {
+		fmt.Printf("  %s: local/linked module, nothing to update\n", name)
+		return nil
+	}
+
+	cache, err := ensureCache(entry.RepoURL)
+	if err != nil {
+		return err
+	}
+
+	commit, err := resolveSHA(cache, "HEAD")
+	if err != nil {
+		return err
+	}
+
+	if commit == entry.Commit {
+		fmt.Printf("  %s: already up to date (%s)\n", name, shortSHA(commit))
+		return nil
+	}
+
+	wt, err := addWorktree(cache, "HEAD")
+	if err != nil {
+		return err
+	}
+	defer removeWorktree(cache, wt)
+
+	srcDir := wt
+	if entry.SubPath != "" {
+		moduleDir := filepath.Join(wt, entry.SubPath)
+		version, err := latestVersion(moduleDir, entry.Constraint)
+		if err != nil {
+			return fmt.Errorf("no versions found satisfying %q: %w", entry.Constraint, err)
+		}
+		srcDir = filepath.Join(moduleDir, version)
+	}
+
+	manifestPath := filepath.Join(srcDir, "manifest.yaml")
+	manifest, err := readManifest(manifestPath)
+	if err != nil {
+		return fmt.Errorf("manifest: %w", err)
+	}
+
+	// re-verify on update; a failure aborts just like install, unless the
+	// caller explicitly passed --allow-untrusted.
+	manifest.Verified, err = verifyOrAbort(manifestPath, manifest, allowUntrusted)
+	if err != nil {
+		return err
+	}
+
+	return build(name, manifest.Version, srcDir, manifest, entry.Official, entry.RepoURL, entry.SubPath, entry.Constraint, commit)
+}
+
+// shortSHA truncates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
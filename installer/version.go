@@ -0,0 +1,102 @@
+package installer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// canonical ensures a version string carries the "v" prefix semver.* expects.
+func canonical(v string) string {
+	if v == "" || v[0] == 'v' {
+		return v
+	}
+	return "v" + v
+}
+
+// MatchVersion picks the highest version in available that satisfies constraint,
+// sorting with proper semver precedence rather than lexicographic directory order.
+// constraint may be empty ("latest"), an exact pin ("=1.2.3"), a wildcard
+// ("1.2.x"), or a caret range ("^1.2").
+func MatchVersion(available []string, constraint string) (string, error) {
+	var best string
+	for _, v := range available {
+		ok, err := SatisfiesConstraint(v, constraint)
+		if err != nil || !ok {
+			continue
+		}
+		if best == "" || semver.Compare(canonical(v), canonical(best)) > 0 {
+			best = v
+		}
+	}
+	if best == "" {
+		if constraint == "" {
+			return "", fmt.Errorf("no valid semver versions found")
+		}
+		return "", fmt.Errorf("no version satisfies constraint %q", constraint)
+	}
+	return best, nil
+}
+
+// SatisfiesConstraint reports whether version satisfies constraint.
+//
+// Supported forms:
+//
+//	""         any valid semver version
+//	"=1.2.3"   exact match
+//	"1.2.x"    wildcard — major.minor must match, patch is unconstrained
+//	"^1.2"     caret range — >=1.2.0, <2.0.0 (or the 0.x equivalent)
+func SatisfiesConstraint(version, constraint string) (bool, error) {
+	cv := canonical(version)
+	if !semver.IsValid(cv) {
+		return false, fmt.Errorf("invalid version %q", version)
+	}
+	if constraint == "" {
+		return true, nil
+	}
+
+	switch {
+	case strings.HasPrefix(constraint, "="):
+		want := canonical(strings.TrimPrefix(constraint, "="))
+		if !semver.IsValid(want) {
+			return false, fmt.Errorf("invalid constraint %q", constraint)
+		}
+		return semver.Compare(cv, want) == 0, nil
+
+	case strings.HasPrefix(constraint, "^"):
+		base := canonical(strings.TrimPrefix(constraint, "^"))
+		if !semver.IsValid(base) {
+			return false, fmt.Errorf("invalid constraint %q", constraint)
+		}
+		return semver.Compare(cv, base) >= 0 && semver.Compare(cv, caretUpperBound(base)) < 0, nil
+
+	case strings.HasSuffix(constraint, ".x"):
+		prefix := canonical(strings.TrimSuffix(constraint, ".x"))
+		return semver.MajorMinor(cv) == prefix, nil
+
+	default:
+		want := canonical(constraint)
+		if !semver.IsValid(want) {
+			return false, fmt.Errorf("invalid constraint %q", constraint)
+		}
+		return semver.Compare(cv, want) == 0, nil
+	}
+}
+
+// caretUpperBound computes the exclusive upper bound of a caret range: the
+// next release that would break compatibility under semver rules.
+func caretUpperBound(base string) string {
+	parts := strings.SplitN(strings.TrimPrefix(base, "v"), ".", 3)
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	major, _ := strconv.Atoi(parts[0])
+	minor, _ := strconv.Atoi(parts[1])
+
+	if major > 0 {
+		return fmt.Sprintf("v%d.0.0", major+1)
+	}
+	return fmt.Sprintf("v0.%d.0", minor+1)
+}
@@ -0,0 +1,72 @@
+package installer
+
+import "testing"
+
+func TestSatisfiesConstraint(t *testing.T) {
+	cases := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"1.2.3", "", true},
+		{"1.2.3", "=1.2.3", true},
+		{"1.2.4", "=1.2.3", false},
+		{"1.2.9", "1.2.x", true},
+		{"1.3.0", "1.2.x", false},
+		{"1.2.3", "^1.2", true},
+		{"1.9.9", "^1.2", true},
+		{"2.0.0", "^1.2", false},
+		{"0.2.5", "^0.2", true},
+		{"0.3.0", "^0.2", false},
+		{"1.2.3", "1.2.3", true},
+	}
+
+	for _, c := range cases {
+		got, err := SatisfiesConstraint(c.version, c.constraint)
+		if err != nil {
+			t.Errorf("SatisfiesConstraint(%q, %q) returned error: %v", c.version, c.constraint, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("SatisfiesConstraint(%q, %q) = %v, want %v", c.version, c.constraint, got, c.want)
+		}
+	}
+}
+
+func TestSatisfiesConstraintInvalid(t *testing.T) {
+	if _, err := SatisfiesConstraint("not-a-version", ""); err == nil {
+		t.Error("expected error for invalid version, got nil")
+	}
+	if _, err := SatisfiesConstraint("1.2.3", "=not-a-version"); err == nil {
+		t.Error("expected error for invalid constraint, got nil")
+	}
+}
+
+func TestMatchVersion(t *testing.T) {
+	available := []string{"1.0.0", "1.2.0", "1.2.9", "1.3.0", "2.0.0"}
+
+	got, err := MatchVersion(available, "^1.2")
+	if err != nil {
+		t.Fatalf("MatchVersion returned error: %v", err)
+	}
+	if got != "1.3.0" {
+		t.Errorf("MatchVersion(^1.2) = %q, want %q", got, "1.3.0")
+	}
+
+	got, err = MatchVersion(available, "")
+	if err != nil {
+		t.Fatalf("MatchVersion returned error: %v", err)
+	}
+	if got != "2.0.0" {
+		t.Errorf("MatchVersion(latest) = %q, want %q", got, "2.0.0")
+	}
+}
+
+func TestMatchVersionNoMatch(t *testing.T) {
+	if _, err := MatchVersion([]string{"1.0.0"}, "^2.0"); err == nil {
+		t.Error("expected error when no version satisfies the constraint, got nil")
+	}
+	if _, err := MatchVersion(nil, ""); err == nil {
+		t.Error("expected error when no versions are available, got nil")
+	}
+}
@@ -7,8 +7,9 @@ import (
 )
 
 type Input struct {
-	Input  string         `json:"input"`
-	Config map[string]any `json:"config"`
+	Input  string            `json:"input"`
+	Inputs map[string]string `json:"inputs,omitempty"` // aliased literals or resolved artifact paths, for multi-input steps
+	Config map[string]any    `json:"config"`
 }
 
 type Context struct {